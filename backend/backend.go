@@ -0,0 +1,163 @@
+// Package backend provides the interfaces that the Terraform CLI uses to
+// execute operations (plan, apply, etc.) against local or remote state.
+//
+// This file only reproduces the subset of backend.Operation and
+// backend.RunningOperation that backend/local actually depends on; the
+// rest of the real type lives alongside the other backend implementations.
+package backend
+
+import (
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PlanOutputFormat selects how a completed plan is rendered to the user
+// in addition to the default colorized text diff, which is rendered
+// regardless of this setting.
+type PlanOutputFormat string
+
+const (
+	// PlanOutFormatText is the default human-readable colorized diff
+	// produced by command/format.Plan.
+	PlanOutFormatText PlanOutputFormat = "text"
+
+	// PlanOutFormatJSON serializes the plan to a stable, machine-readable
+	// JSON document suitable for CI gating, policy checks, and
+	// third-party visualization.
+	PlanOutFormatJSON PlanOutputFormat = "json"
+)
+
+// Operation represents an operation for Terraform to execute.
+type Operation struct {
+	// Type is the operation to perform.
+	Type OperationType
+
+	Module    string
+	Workspace string
+
+	// PlanRefresh indicates whether a refresh should be performed before
+	// computing a plan.
+	PlanRefresh bool
+
+	// Plan is a saved plan to use for the operation. If this is set,
+	// no new plan is calculated.
+	Plan *terraform.Plan
+
+	// PlanPath is the path Plan was loaded from, if any. It's used to
+	// locate the plan's detached signature sidecar file.
+	PlanPath string
+
+	// PlanOutPath is the path to save a generated plan, or empty to skip
+	// saving the plan at all.
+	PlanOutPath    string
+	PlanOutBackend *terraform.BackendState
+
+	// PlanOutFormat controls how the plan is rendered on completion, in
+	// addition to being saved to PlanOutPath. Defaults to
+	// PlanOutFormatText when empty.
+	PlanOutFormat PlanOutputFormat
+
+	// PlanJSONOutPath is the path to additionally write the JSON plan
+	// representation, independent of PlanOutFormat. If empty and
+	// PlanOutFormat is PlanOutFormatJSON, the JSON is written to stdout.
+	PlanJSONOutPath string
+
+	// PlanSigner, if set, is used to produce a detached signature
+	// alongside any plan written to PlanOutPath, and to verify the
+	// signature of any plan loaded via Plan.
+	PlanSigner PlanSigner
+
+	// PlanSignaturePolicy controls what happens when PlanSigner is set
+	// and a loaded plan's signature is missing or invalid.
+	PlanSignaturePolicy PlanSignaturePolicy
+
+	// PolicyEvaluators run against the computed plan before it's written
+	// to PlanOutPath or displayed. Violations at PolicySeverityError
+	// abort the operation and suppress plan file creation.
+	PolicyEvaluators []PolicyEvaluator
+
+	// RefreshConcurrency bounds how many nodes of the refresh graph walk
+	// may run concurrently, by way of terraform.ContextOpts.Parallelism.
+	// It only has an effect when PlanRefresh is set; a zero value leaves
+	// Parallelism at whatever it's already set to (terraform's own
+	// default, absent other configuration). Because Parallelism is fixed
+	// for the lifetime of the terraform.Context built for this operation,
+	// enabling it also bounds the plan graph walk that follows the
+	// refresh on that same Context.
+	RefreshConcurrency int
+}
+
+// PolicyEvaluator evaluates a computed plan against a set of policy rules,
+// returning any violations found.
+type PolicyEvaluator interface {
+	Evaluate(plan *terraform.Plan) ([]PolicyViolation, error)
+}
+
+// PolicyViolation describes a single rule failure found while evaluating
+// a plan.
+type PolicyViolation struct {
+	Rule            string
+	Severity        PolicySeverity
+	Message         string
+	ResourceAddress string
+}
+
+// PolicySeverity controls whether a PolicyViolation blocks the operation
+// or is merely surfaced to the user.
+type PolicySeverity string
+
+const (
+	// PolicySeverityError aborts the operation and suppresses plan
+	// file creation.
+	PolicySeverityError PolicySeverity = "error"
+
+	// PolicySeverityWarn is rendered alongside the plan summary but
+	// doesn't block the operation.
+	PolicySeverityWarn PolicySeverity = "warn"
+)
+
+// PlanSigner produces and verifies detached signatures over saved plan
+// files, so that a plan can't be tampered with between being written by
+// "plan" and consumed by "apply".
+type PlanSigner interface {
+	// Sign returns a detached signature over plan's serialized bytes.
+	Sign(plan []byte) ([]byte, error)
+
+	// Verify checks sig against plan's serialized bytes, returning an
+	// error if the signature is missing or doesn't match.
+	Verify(plan []byte, sig []byte) error
+
+	// Identity returns a human-readable identifier for the signer (e.g.
+	// a key fingerprint or operator name), shown in CLI output when a
+	// plan is signed or verified. It is not written into the plan file.
+	Identity() string
+}
+
+// PlanSignaturePolicy controls how a missing or invalid plan signature is
+// treated when PlanSigner is configured.
+type PlanSignaturePolicy string
+
+const (
+	// PlanSignaturePolicyEnforce causes an invalid or missing signature
+	// to hard-fail the operation.
+	PlanSignaturePolicyEnforce PlanSignaturePolicy = "enforce"
+
+	// PlanSignaturePolicyWarn causes an invalid or missing signature to
+	// print a warning but allow the operation to proceed.
+	PlanSignaturePolicyWarn PlanSignaturePolicy = "warn"
+)
+
+// OperationType is the type of operation to perform.
+type OperationType byte
+
+// RunningOperation is the result of starting an operation.
+type RunningOperation struct {
+	// Err is the error from the operation, if any.
+	Err error
+
+	// State is the final state after the operation completed.
+	State *terraform.State
+
+	// PlanEmpty is true if the operation was a plan and the resulting
+	// plan was empty (no changes).
+	PlanEmpty bool
+}
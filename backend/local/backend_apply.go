@@ -0,0 +1,72 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func (b *Local) opApply(
+	ctx context.Context,
+	op *backend.Operation,
+	runningOp *backend.RunningOperation) {
+	log.Printf("[INFO] backend/local: starting Apply operation")
+
+	// A saved plan's signature is checked before the plan is allowed to
+	// reach tfCtx.Apply(), and a bad one blocks it unless
+	// op.PlanSignaturePolicy is PlanSignaturePolicyWarn; opPlan's
+	// "plan <file>" redisplay path can't stand in
+	// for this, since apply is the point at which the plan actually
+	// executes against real infrastructure.
+	if op.Plan != nil && op.PlanSigner != nil {
+		if err := b.verifyPlanSignature(op); err != nil {
+			runningOp.Err = err
+			return
+		}
+	}
+
+	// Setup our count hook that keeps track of resource changes
+	countHook := new(CountHook)
+	if b.ContextOpts == nil {
+		b.ContextOpts = new(terraform.ContextOpts)
+	}
+	old := b.ContextOpts.Hooks
+	defer func() { b.ContextOpts.Hooks = old }()
+	b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, countHook)
+
+	// Get our context
+	tfCtx, _, err := b.context(op)
+	if err != nil {
+		runningOp.Err = err
+		return
+	}
+
+	// Setup the state
+	runningOp.State = tfCtx.State()
+
+	// Perform the apply
+	log.Printf("[INFO] backend/local: apply calling Apply")
+	applyState, err := tfCtx.Apply()
+
+	// Record the resulting state, even if Apply returned an error, since
+	// a partial apply still needs to be persisted.
+	runningOp.State = applyState
+
+	if b.CLI != nil {
+		b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+			"[reset][bold]Apply complete![reset] Resources: "+
+				"%d added, %d changed, %d destroyed.",
+			countHook.ToAdd+countHook.ToRemoveAndAdd,
+			countHook.ToChange,
+			countHook.ToRemove+countHook.ToRemoveAndAdd)))
+	}
+
+	if err != nil {
+		runningOp.Err = errwrap.Wrapf("Error applying plan: {{err}}", err)
+		return
+	}
+}
@@ -1,8 +1,10 @@
 package local
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"strings"
@@ -28,6 +30,13 @@ func (b *Local) opPlan(
 				"directory as an argument.\n\n"))
 	}
 
+	if op.Plan != nil && op.PlanSigner != nil {
+		if err := b.verifyPlanSignature(op); err != nil {
+			runningOp.Err = err
+			return
+		}
+	}
+
 	// Setup our count hook that keeps track of resource changes
 	countHook := new(CountHook)
 	if b.ContextOpts == nil {
@@ -35,7 +44,27 @@ func (b *Local) opPlan(
 	}
 	old := b.ContextOpts.Hooks
 	defer func() { b.ContextOpts.Hooks = old }()
-	b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, countHook)
+	progress := &RefreshProgressHook{CLI: b.CLI}
+	b.ContextOpts.Hooks = append(b.ContextOpts.Hooks, countHook, progress)
+
+	// Refresh (like Plan and Apply) walks the dependency graph through
+	// terraform.ContextOpts.Parallelism, which already bounds how many
+	// graph nodes run concurrently while respecting dependency ordering.
+	// Route RefreshConcurrency through the same knob instead of adding a
+	// second, competing one.
+	//
+	// terraform.Context bakes Parallelism in at construction and reuses
+	// the same semaphore for every graph walk it performs afterwards, so
+	// this can only be scoped to "this Context", not to "just the
+	// Refresh() call" within it. Only apply the override when a refresh
+	// is actually going to run; when it does, it also bounds the
+	// subsequent Plan() walk on the same Context, which is an accepted
+	// side effect rather than an independent "plan concurrency" knob.
+	if op.PlanRefresh && op.RefreshConcurrency > 0 {
+		oldParallelism := b.ContextOpts.Parallelism
+		b.ContextOpts.Parallelism = op.RefreshConcurrency
+		defer func() { b.ContextOpts.Parallelism = oldParallelism }()
+	}
 
 	// Get our context
 	tfCtx, _, err := b.context(op)
@@ -46,6 +75,7 @@ func (b *Local) opPlan(
 
 	// Setup the state
 	runningOp.State = tfCtx.State()
+	progress.Total = countStateResources(runningOp.State)
 
 	// If we're refreshing before plan, perform that
 	if op.PlanRefresh {
@@ -70,21 +100,60 @@ func (b *Local) opPlan(
 	// Record state
 	runningOp.PlanEmpty = plan.Diff.Empty()
 
+	// Run any configured policy checks against the plan before it's
+	// persisted or displayed.
+	warnings, err := b.evaluatePolicies(op, plan)
+	if err != nil {
+		runningOp.Err = err
+		return
+	}
+
 	// Save the plan to disk
 	if path := op.PlanOutPath; path != "" {
 		// Write the backend if we have one
 		plan.Backend = op.PlanOutBackend
 
 		log.Printf("[INFO] backend/local: writing plan output to: %s", path)
-		f, err := os.Create(path)
+		var buf bytes.Buffer
+		err = terraform.WritePlan(plan, &buf)
 		if err == nil {
-			err = terraform.WritePlan(plan, f)
+			err = ioutil.WriteFile(path, buf.Bytes(), 0644)
 		}
-		f.Close()
 		if err != nil {
 			runningOp.Err = fmt.Errorf("Error writing plan file: %s", err)
 			return
 		}
+
+		if op.PlanSigner != nil {
+			if err := b.signPlan(op, path, buf.Bytes()); err != nil {
+				runningOp.Err = err
+				return
+			}
+			if b.CLI != nil {
+				b.CLI.Output(fmt.Sprintf(
+					"[reset][bold]Plan signed by %s[reset] (%s)",
+					op.PlanSigner.Identity(), path+planSigExt))
+			}
+		}
+	}
+
+	// Emit the JSON plan representation and any policy warnings
+	// unconditionally, before the CLI's empty-diff early return below:
+	// "no drift" is itself the result CI gating and policy checks care
+	// about most, so it must not be swallowed by a no-op plan.
+	if op.PlanOutFormat == backend.PlanOutFormatJSON || op.PlanJSONOutPath != "" {
+		if err := b.writePlanJSON(op, plan, countHook); err != nil {
+			runningOp.Err = fmt.Errorf("Error writing JSON plan: %s", err)
+			return
+		}
+	}
+
+	if b.CLI != nil {
+		for _, v := range warnings {
+			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+				"[reset][bold][yellow]Policy warning (%s): %s[reset]",
+				v.Rule, v.Message)))
+		}
 	}
 
 	// Perform some output tasks if we have a CLI to output to.
@@ -121,6 +190,99 @@ func (b *Local) opPlan(
 	}
 }
 
+// evaluatePolicies runs every configured policy evaluator against plan. An
+// error-severity violation is returned as an error, which aborts opPlan
+// and suppresses plan file creation; warn-severity violations are
+// returned for the caller to render alongside the plan summary.
+func (b *Local) evaluatePolicies(op *backend.Operation, plan *terraform.Plan) ([]backend.PolicyViolation, error) {
+	var warnings []backend.PolicyViolation
+	for _, evaluator := range op.PolicyEvaluators {
+		violations, err := evaluator.Evaluate(plan)
+		if err != nil {
+			return nil, fmt.Errorf("Error evaluating policy: %s", err)
+		}
+
+		for _, v := range violations {
+			if v.Severity == backend.PolicySeverityError {
+				return nil, fmt.Errorf("Policy violation (%s): %s", v.Rule, v.Message)
+			}
+			warnings = append(warnings, v)
+		}
+	}
+	return warnings, nil
+}
+
+// signPlan writes a detached signature for the plan bytes already written
+// to path, alongside it at path+planSigExt.
+func (b *Local) signPlan(op *backend.Operation, path string, planBytes []byte) error {
+	sig, err := op.PlanSigner.Sign(planBytes)
+	if err != nil {
+		return fmt.Errorf("Error signing plan file: %s", err)
+	}
+	if err := ioutil.WriteFile(path+planSigExt, sig, 0644); err != nil {
+		return fmt.Errorf("Error writing plan signature: %s", err)
+	}
+	return nil
+}
+
+// verifyPlanSignature checks the detached signature of a saved plan loaded
+// via op.Plan/op.PlanPath against op.PlanSigner before the plan is used.
+// Under PlanSignaturePolicyWarn a bad signature is reported but does not
+// block the operation; otherwise (the default) it does.
+func (b *Local) verifyPlanSignature(op *backend.Operation) error {
+	planBytes, err := ioutil.ReadFile(op.PlanPath)
+	if err != nil {
+		return fmt.Errorf("Error reading plan file for signature verification: %s", err)
+	}
+	sig, err := ioutil.ReadFile(op.PlanPath + planSigExt)
+	if err != nil {
+		sig = nil
+	}
+
+	verifyErr := op.PlanSigner.Verify(planBytes, sig)
+	if verifyErr == nil {
+		if b.CLI != nil {
+			b.CLI.Output(fmt.Sprintf(
+				"[reset][bold][green]Plan signature verified[reset] (signer: %s)",
+				op.PlanSigner.Identity()))
+		}
+		return nil
+	}
+
+	if op.PlanSignaturePolicy == backend.PlanSignaturePolicyWarn {
+		if b.CLI != nil {
+			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+				"[reset][bold][yellow]Warning: plan signature verification failed: %s[reset]",
+				verifyErr)))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("Plan signature verification failed: %s", verifyErr)
+}
+
+// writePlanJSON renders plan as the stable machine-readable JSON document,
+// writing it to op.PlanJSONOutPath if set, or to the CLI's stdout otherwise.
+func (b *Local) writePlanJSON(op *backend.Operation, plan *terraform.Plan, countHook *CountHook) error {
+	if path := op.PlanJSONOutPath; path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return writePlanJSON(f, plan, countHook)
+	}
+
+	if b.CLI != nil {
+		var buf bytes.Buffer
+		if err := writePlanJSON(&buf, plan, countHook); err != nil {
+			return err
+		}
+		b.CLI.Output(buf.String())
+	}
+	return nil
+}
+
 const planHeaderNoOutput = `
 The Terraform execution plan has been generated and is shown below.
 Resources are shown in alphabetical order for quick scanning. Green resources
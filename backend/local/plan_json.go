@@ -0,0 +1,135 @@
+package local
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// isDataSourceAddress reports whether a resource address (as used as a key
+// in terraform.ModuleDiff.Resources) refers to a data source rather than a
+// managed resource, the same "data." prefix check command/format uses.
+func isDataSourceAddress(addr string) bool {
+	return strings.HasPrefix(addr, "data.")
+}
+
+// fullResourceAddress builds a dotted Terraform resource address from a
+// module diff's Path and a resource's address within that module, e.g.
+// "module.foo.aws_instance.bar" — the same convention terraform.State and
+// command/format use to identify a resource, not an OS path join.
+func fullResourceAddress(modulePath []string, resourceAddress string) string {
+	segments := make([]string, 0, len(modulePath)*2+1)
+	for _, p := range modulePath {
+		if p == "root" {
+			continue
+		}
+		segments = append(segments, "module", p)
+	}
+	segments = append(segments, resourceAddress)
+	return strings.Join(segments, ".")
+}
+
+// jsonPlanFormatVersion is embedded in every JSON plan document so that
+// consumers can detect schema changes going forward.
+const jsonPlanFormatVersion = "0.1"
+
+// jsonPlan is the root of the machine-readable plan document written when
+// backend.Operation.PlanOutFormat is backend.PlanOutFormatJSON or
+// backend.Operation.PlanJSONOutPath is set.
+type jsonPlan struct {
+	FormatVersion string           `json:"format_version"`
+	Modules       []jsonModuleDiff `json:"module_diffs"`
+	Summary       jsonPlanSummary  `json:"summary"`
+}
+
+type jsonModuleDiff struct {
+	Path      []string           `json:"path"`
+	Resources []jsonResourceDiff `json:"resources"`
+}
+
+type jsonResourceDiff struct {
+	Address        string                    `json:"address"`
+	DataSource     bool                      `json:"data_source"`
+	Destroy        bool                      `json:"destroy"`
+	DestroyDeposed bool                      `json:"destroy_deposed"`
+	Attributes     map[string]jsonAttrChange `json:"attributes"`
+}
+
+type jsonAttrChange struct {
+	Old         string `json:"old"`
+	New         string `json:"new"`
+	NewComputed bool   `json:"new_computed"`
+	Sensitive   bool   `json:"sensitive"`
+	RequiresNew bool   `json:"requires_new"`
+}
+
+type jsonPlanSummary struct {
+	Add    int `json:"add"`
+	Change int `json:"change"`
+	Remove int `json:"remove"`
+}
+
+// writePlanJSON serializes plan to the stable JSON schema described above,
+// folding in the add/change/remove counts already tallied by countHook.
+func writePlanJSON(w io.Writer, plan *terraform.Plan, countHook *CountHook) error {
+	out := jsonPlan{
+		FormatVersion: jsonPlanFormatVersion,
+		Summary: jsonPlanSummary{
+			Add:    countHook.ToAdd + countHook.ToRemoveAndAdd,
+			Change: countHook.ToChange,
+			Remove: countHook.ToRemove + countHook.ToRemoveAndAdd,
+		},
+	}
+
+	out.Modules = buildModuleDiffs(plan)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// buildModuleDiffs flattens plan's diff into the module/resource/attribute
+// shape shared by the JSON plan output and the policy evaluation input, so
+// the two can't drift apart. Modules are sorted by Path and resources
+// within a module by Address so the result is stable across runs of the
+// same plan, regardless of Go's randomized map iteration order.
+func buildModuleDiffs(plan *terraform.Plan) []jsonModuleDiff {
+	if plan == nil || plan.Diff == nil {
+		return nil
+	}
+
+	var modules []jsonModuleDiff
+	for _, md := range plan.Diff.Modules {
+		jmd := jsonModuleDiff{Path: md.Path}
+		for name, rd := range md.Resources {
+			jrd := jsonResourceDiff{
+				Address:        name,
+				DataSource:     isDataSourceAddress(name),
+				Destroy:        rd.Destroy,
+				DestroyDeposed: rd.DestroyDeposed,
+				Attributes:     make(map[string]jsonAttrChange, len(rd.Attributes)),
+			}
+			for attr, ad := range rd.Attributes {
+				jrd.Attributes[attr] = jsonAttrChange{
+					Old:         ad.Old,
+					New:         ad.New,
+					NewComputed: ad.NewComputed,
+					Sensitive:   ad.Sensitive,
+					RequiresNew: ad.RequiresNew,
+				}
+			}
+			jmd.Resources = append(jmd.Resources, jrd)
+		}
+		sort.Slice(jmd.Resources, func(i, j int) bool {
+			return jmd.Resources[i].Address < jmd.Resources[j].Address
+		})
+		modules = append(modules, jmd)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return strings.Join(modules[i].Path, ".") < strings.Join(modules[j].Path, ".")
+	})
+	return modules
+}
@@ -0,0 +1,149 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testPlanForJSON() *terraform.Plan {
+	return &terraform.Plan{
+		Diff: &terraform.Diff{
+			Modules: []*terraform.ModuleDiff{
+				{
+					Path: []string{"root", "z-module"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"aws_instance.b": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"ami": {Old: "ami-1", New: "ami-2"},
+							},
+						},
+						"aws_instance.a": {
+							Destroy: true,
+						},
+					},
+				},
+				{
+					Path: []string{"root"},
+					Resources: map[string]*terraform.InstanceDiff{
+						"data.aws_ami.latest": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"id": {NewComputed: true},
+							},
+						},
+						"aws_instance.web": {
+							Attributes: map[string]*terraform.ResourceAttrDiff{
+								"tags.%": {RequiresNew: true, Sensitive: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildModuleDiffs_sortedAndClassified(t *testing.T) {
+	modules := buildModuleDiffs(testPlanForJSON())
+
+	if len(modules) != 2 {
+		t.Fatalf("expected 2 modules, got %d", len(modules))
+	}
+
+	// "root" must sort before "root.z-module".
+	if got, want := modules[0].Path, []string{"root"}; !equalStringSlices(got, want) {
+		t.Fatalf("modules[0].Path = %v, want %v", got, want)
+	}
+	if got, want := modules[1].Path, []string{"root", "z-module"}; !equalStringSlices(got, want) {
+		t.Fatalf("modules[1].Path = %v, want %v", got, want)
+	}
+
+	rootResources := modules[0].Resources
+	if len(rootResources) != 2 {
+		t.Fatalf("expected 2 resources in root module, got %d", len(rootResources))
+	}
+	if rootResources[0].Address != "aws_instance.web" || rootResources[1].Address != "data.aws_ami.latest" {
+		t.Fatalf("resources not sorted by address: %#v", rootResources)
+	}
+	if !rootResources[1].DataSource {
+		t.Fatal("expected data.aws_ami.latest to be classified as a data source")
+	}
+	if rootResources[0].DataSource {
+		t.Fatal("expected aws_instance.web to not be classified as a data source")
+	}
+
+	zModuleResources := modules[1].Resources
+	if zModuleResources[0].Address != "aws_instance.a" || !zModuleResources[0].Destroy {
+		t.Fatalf("expected aws_instance.a to sort first and be a destroy: %#v", zModuleResources[0])
+	}
+}
+
+func TestBuildModuleDiffs_stableAcrossRuns(t *testing.T) {
+	plan := testPlanForJSON()
+	first, err := json.Marshal(buildModuleDiffs(plan))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		again, err := json.Marshal(buildModuleDiffs(plan))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatalf("buildModuleDiffs produced different output across runs:\n%s\nvs\n%s", first, again)
+		}
+	}
+}
+
+func TestWritePlanJSON(t *testing.T) {
+	var buf bytes.Buffer
+	countHook := &CountHook{ToAdd: 2, ToChange: 1, ToRemove: 1}
+	if err := writePlanJSON(&buf, testPlanForJSON(), countHook); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var out jsonPlan
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("writePlanJSON did not produce valid JSON: %s", err)
+	}
+
+	if out.Summary.Add != 2 || out.Summary.Change != 1 || out.Summary.Remove != 1 {
+		t.Fatalf("unexpected summary: %#v", out.Summary)
+	}
+	if len(out.Modules) != 2 {
+		t.Fatalf("expected 2 modules in JSON output, got %d", len(out.Modules))
+	}
+}
+
+func TestFullResourceAddress(t *testing.T) {
+	cases := []struct {
+		modulePath []string
+		address    string
+		want       string
+	}{
+		{[]string{"root"}, "aws_instance.web", "aws_instance.web"},
+		{[]string{"root", "foo"}, "aws_instance.web", "module.foo.aws_instance.web"},
+		{[]string{"root", "foo", "bar"}, "aws_instance.web", "module.foo.module.bar.aws_instance.web"},
+	}
+
+	for _, c := range cases {
+		if got := fullResourceAddress(c.modulePath, c.address); got != c.want {
+			t.Errorf("fullResourceAddress(%v, %q) = %q, want %q", c.modulePath, c.address, got, c.want)
+		}
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
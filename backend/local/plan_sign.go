@@ -0,0 +1,88 @@
+package local
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hashicorp/terraform/backend"
+)
+
+// planSigExt is appended to a plan's PlanOutPath to form the path of its
+// detached signature.
+const planSigExt = ".sig"
+
+// Ed25519PlanSigner is the default backend.PlanSigner implementation. It
+// signs and verifies plan files using a single ed25519 key pair, loaded
+// either from a file on disk or from a base64-encoded environment
+// variable.
+type Ed25519PlanSigner struct {
+	identity string
+	priv     ed25519.PrivateKey
+	pub      ed25519.PublicKey
+}
+
+// NewEd25519PlanSignerFromFile loads a private key from keyPath (raw
+// 64-byte ed25519 seed+public key, base64-encoded) and names the signer
+// identity for use in CLI output; the identity is not written into the
+// plan file.
+func NewEd25519PlanSignerFromFile(keyPath, identity string) (*Ed25519PlanSigner, error) {
+	raw, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading plan signing key %q: %s", keyPath, err)
+	}
+	return newEd25519PlanSigner(string(raw), identity)
+}
+
+// NewEd25519PlanSignerFromEnv loads a base64-encoded private key from the
+// named environment variable.
+func NewEd25519PlanSignerFromEnv(envVar, identity string) (*Ed25519PlanSigner, error) {
+	raw := os.Getenv(envVar)
+	if raw == "" {
+		return nil, fmt.Errorf("environment variable %q is not set", envVar)
+	}
+	return newEd25519PlanSigner(raw, identity)
+}
+
+func newEd25519PlanSigner(encodedKey, identity string) (*Ed25519PlanSigner, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding plan signing key: %s", err)
+	}
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("plan signing key must be %d bytes, got %d", ed25519.PrivateKeySize, len(key))
+	}
+
+	priv := ed25519.PrivateKey(key)
+	return &Ed25519PlanSigner{
+		identity: identity,
+		priv:     priv,
+		pub:      priv.Public().(ed25519.PublicKey),
+	}, nil
+}
+
+func (s *Ed25519PlanSigner) Sign(plan []byte) ([]byte, error) {
+	return ed25519.Sign(s.priv, plan), nil
+}
+
+func (s *Ed25519PlanSigner) Verify(plan []byte, sig []byte) error {
+	if len(sig) == 0 {
+		return fmt.Errorf("plan has no signature")
+	}
+	if !ed25519.Verify(s.pub, plan, sig) {
+		return fmt.Errorf("plan signature does not match key %s", s.Identity())
+	}
+	return nil
+}
+
+func (s *Ed25519PlanSigner) Identity() string {
+	if s.identity != "" {
+		return s.identity
+	}
+	return hex.EncodeToString(s.pub)
+}
+
+var _ backend.PlanSigner = (*Ed25519PlanSigner)(nil)
@@ -0,0 +1,109 @@
+package local
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+)
+
+func testEd25519Signer(t *testing.T) *Ed25519PlanSigner {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating test key: %s", err)
+	}
+
+	dir, err := ioutil.TempDir("", "tf-plan-sign")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyPath := filepath.Join(dir, "signer.key")
+	encoded := base64.StdEncoding.EncodeToString(priv)
+	if err := ioutil.WriteFile(keyPath, []byte(encoded), 0600); err != nil {
+		t.Fatalf("error writing test key: %s", err)
+	}
+
+	signer, err := NewEd25519PlanSignerFromFile(keyPath, "test-signer")
+	if err != nil {
+		t.Fatalf("error loading test signer: %s", err)
+	}
+	return signer
+}
+
+func TestEd25519PlanSigner_signVerifyRoundTrip(t *testing.T) {
+	signer := testEd25519Signer(t)
+
+	plan := []byte("fake serialized plan bytes")
+	sig, err := signer.Sign(plan)
+	if err != nil {
+		t.Fatalf("error signing plan: %s", err)
+	}
+
+	if err := signer.Verify(plan, sig); err != nil {
+		t.Fatalf("expected signature to verify, got: %s", err)
+	}
+}
+
+func TestEd25519PlanSigner_verifyTamperedPlan(t *testing.T) {
+	signer := testEd25519Signer(t)
+
+	sig, err := signer.Sign([]byte("original plan bytes"))
+	if err != nil {
+		t.Fatalf("error signing plan: %s", err)
+	}
+
+	if err := signer.Verify([]byte("tampered plan bytes"), sig); err == nil {
+		t.Fatal("expected verification of a tampered plan to fail")
+	}
+}
+
+func TestLocalVerifyPlanSignature(t *testing.T) {
+	signer := testEd25519Signer(t)
+
+	dir, err := ioutil.TempDir("", "tf-plan-verify")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	planPath := filepath.Join(dir, "plan.tfplan")
+	planBytes := []byte("fake serialized plan bytes")
+	if err := ioutil.WriteFile(planPath, planBytes, 0644); err != nil {
+		t.Fatalf("error writing test plan: %s", err)
+	}
+
+	sig, err := signer.Sign(planBytes)
+	if err != nil {
+		t.Fatalf("error signing plan: %s", err)
+	}
+	if err := ioutil.WriteFile(planPath+planSigExt, sig, 0644); err != nil {
+		t.Fatalf("error writing test signature: %s", err)
+	}
+
+	b := &Local{}
+	op := &backend.Operation{PlanPath: planPath, PlanSigner: signer}
+	if err := b.verifyPlanSignature(op); err != nil {
+		t.Fatalf("expected valid signature to verify, got: %s", err)
+	}
+
+	// Tamper with the plan after it was signed.
+	if err := ioutil.WriteFile(planPath, []byte("tampered plan bytes"), 0644); err != nil {
+		t.Fatalf("error tampering with test plan: %s", err)
+	}
+
+	if err := b.verifyPlanSignature(op); err == nil {
+		t.Fatal("expected a tampered plan to fail verification under the default enforce policy")
+	}
+
+	op.PlanSignaturePolicy = backend.PlanSignaturePolicyWarn
+	if err := b.verifyPlanSignature(op); err != nil {
+		t.Fatalf("warn policy should not block on a bad signature, got: %s", err)
+	}
+}
@@ -0,0 +1,151 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+// RegoPolicyEvaluator is the default backend.PolicyEvaluator. It loads
+// every *.rego file from Dir and evaluates them all against the plan,
+// treating each result produced under "deny" as a PolicySeverityError
+// violation and each result under "warn" as PolicySeverityWarn.
+type RegoPolicyEvaluator struct {
+	Dir string
+}
+
+func (e *RegoPolicyEvaluator) Evaluate(plan *terraform.Plan) ([]backend.PolicyViolation, error) {
+	files, err := filepath.Glob(filepath.Join(e.Dir, "*.rego"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing policy files in %s: %s", e.Dir, err)
+	}
+
+	input, err := policyInput(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []backend.PolicyViolation
+	for _, severity := range []backend.PolicySeverity{backend.PolicySeverityError, backend.PolicySeverityWarn} {
+		query := "data.terraform." + regoRuleName(severity)
+
+		r := rego.New(
+			rego.Query(query),
+			rego.Load(files, nil),
+		)
+
+		pq, err := r.PrepareForEval(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error preparing policy %s: %s", e.Dir, err)
+		}
+
+		rs, err := pq.Eval(context.Background(), rego.EvalInput(input))
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating policy %s: %s", e.Dir, err)
+		}
+
+		for _, v := range regoViolationResults(rs) {
+			violations = append(violations, backend.PolicyViolation{
+				Rule:            query,
+				Severity:        severity,
+				Message:         v.Message,
+				ResourceAddress: v.ResourceAddress,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+func regoRuleName(severity backend.PolicySeverity) string {
+	if severity == backend.PolicySeverityError {
+		return "deny"
+	}
+	return "warn"
+}
+
+// regoViolation is the shape a "deny"/"warn" rego rule is expected to
+// produce for each violation: a set or array of objects naming the
+// resource at fault and a human-readable message.
+type regoViolation struct {
+	ResourceAddress string
+	Message         string
+}
+
+// regoViolationResults flattens a rego.ResultSet of {"resource": ...,
+// "msg": ...} objects into regoViolations, ignoring any result shape it
+// doesn't recognize.
+func regoViolationResults(rs rego.ResultSet) []regoViolation {
+	var out []regoViolation
+	for _, result := range rs {
+		for _, expr := range result.Expressions {
+			values, ok := expr.Value.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, v := range values {
+				obj, ok := v.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				msg, _ := obj["msg"].(string)
+				resource, _ := obj["resource"].(string)
+				out = append(out, regoViolation{
+					ResourceAddress: resource,
+					Message:         msg,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// policyInput builds the JSON-shaped document rego policies evaluate
+// against: the same per-module, per-resource diff data (attributes,
+// destroy and data-source flags, module path) that the JSON plan output
+// exposes, plus a flat list of resource addresses for convenience.
+func policyInput(plan *terraform.Plan) (map[string]interface{}, error) {
+	modules := buildModuleDiffs(plan)
+
+	var resources []string
+	for _, md := range modules {
+		for _, rd := range md.Resources {
+			resources = append(resources, fullResourceAddress(md.Path, rd.Address))
+		}
+	}
+
+	return map[string]interface{}{
+		"modules":   modules,
+		"resources": resources,
+	}, nil
+}
+
+// PredicatePolicyEvaluator is a lightweight backend.PolicyEvaluator backed
+// by a plain Go function, intended for use in tests where loading real
+// rego policy files would be overkill.
+type PredicatePolicyEvaluator struct {
+	Severity  backend.PolicySeverity
+	Rule      string
+	Predicate func(plan *terraform.Plan) (violated bool, message string)
+}
+
+func (e *PredicatePolicyEvaluator) Evaluate(plan *terraform.Plan) ([]backend.PolicyViolation, error) {
+	violated, message := e.Predicate(plan)
+	if !violated {
+		return nil, nil
+	}
+	return []backend.PolicyViolation{{
+		Rule:     e.Rule,
+		Severity: e.Severity,
+		Message:  message,
+	}}, nil
+}
+
+var (
+	_ backend.PolicyEvaluator = (*RegoPolicyEvaluator)(nil)
+	_ backend.PolicyEvaluator = (*PredicatePolicyEvaluator)(nil)
+)
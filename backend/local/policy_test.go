@@ -0,0 +1,73 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/backend"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestLocalEvaluatePolicies_error(t *testing.T) {
+	b := &Local{}
+	op := &backend.Operation{
+		PolicyEvaluators: []backend.PolicyEvaluator{
+			&PredicatePolicyEvaluator{
+				Rule:     "no-delete",
+				Severity: backend.PolicySeverityError,
+				Predicate: func(plan *terraform.Plan) (bool, string) {
+					return true, "destructive change not allowed"
+				},
+			},
+		},
+	}
+
+	if _, err := b.evaluatePolicies(op, &terraform.Plan{}); err == nil {
+		t.Fatal("expected an error-severity violation to abort the plan")
+	}
+}
+
+func TestLocalEvaluatePolicies_warn(t *testing.T) {
+	b := &Local{}
+	op := &backend.Operation{
+		PolicyEvaluators: []backend.PolicyEvaluator{
+			&PredicatePolicyEvaluator{
+				Rule:     "tag-convention",
+				Severity: backend.PolicySeverityWarn,
+				Predicate: func(plan *terraform.Plan) (bool, string) {
+					return true, "missing required tag"
+				},
+			},
+		},
+	}
+
+	warnings, err := b.evaluatePolicies(op, &terraform.Plan{})
+	if err != nil {
+		t.Fatalf("warn-severity violation should not abort the plan: %s", err)
+	}
+	if len(warnings) != 1 || warnings[0].Message != "missing required tag" {
+		t.Fatalf("expected one warning to be returned, got %#v", warnings)
+	}
+}
+
+func TestLocalEvaluatePolicies_clean(t *testing.T) {
+	b := &Local{}
+	op := &backend.Operation{
+		PolicyEvaluators: []backend.PolicyEvaluator{
+			&PredicatePolicyEvaluator{
+				Rule:     "no-delete",
+				Severity: backend.PolicySeverityError,
+				Predicate: func(plan *terraform.Plan) (bool, string) {
+					return false, ""
+				},
+			},
+		},
+	}
+
+	warnings, err := b.evaluatePolicies(op, &terraform.Plan{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %#v", warnings)
+	}
+}
@@ -0,0 +1,53 @@
+package local
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// RefreshProgressHook renders a live "refreshed X/Y resources" counter to
+// the CLI as the plan's refresh phase completes resources, which may now
+// happen concurrently across a worker pool bounded by
+// backend.Operation.RefreshConcurrency.
+type RefreshProgressHook struct {
+	terraform.NilHook
+
+	CLI   cliOutputter
+	Total int
+
+	mu   sync.Mutex
+	done int
+}
+
+// cliOutputter is the subset of cli.Ui that RefreshProgressHook needs,
+// kept narrow so it's trivial to stub in tests.
+type cliOutputter interface {
+	Output(string)
+}
+
+func (h *RefreshProgressHook) PostRefresh(info *terraform.InstanceInfo, s *terraform.InstanceState) (terraform.HookAction, error) {
+	h.mu.Lock()
+	h.done++
+	done := h.done
+	h.mu.Unlock()
+
+	if h.CLI != nil {
+		h.CLI.Output(fmt.Sprintf("refreshed %d/%d resources", done, h.Total))
+	}
+	return terraform.HookActionContinue, nil
+}
+
+// countStateResources returns the number of resource instances tracked in
+// state, used to size a RefreshProgressHook's Total before refresh starts.
+func countStateResources(state *terraform.State) int {
+	if state == nil {
+		return 0
+	}
+	count := 0
+	for _, mod := range state.Modules {
+		count += len(mod.Resources)
+	}
+	return count
+}
@@ -0,0 +1,61 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+type fakeCLIOutputter struct {
+	messages []string
+}
+
+func (c *fakeCLIOutputter) Output(s string) { c.messages = append(c.messages, s) }
+
+func TestRefreshProgressHook_PostRefresh(t *testing.T) {
+	cli := &fakeCLIOutputter{}
+	hook := &RefreshProgressHook{CLI: cli, Total: 2}
+
+	if _, err := hook.PostRefresh(&terraform.InstanceInfo{}, &terraform.InstanceState{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := hook.PostRefresh(&terraform.InstanceInfo{}, &terraform.InstanceState{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(cli.messages) != 2 {
+		t.Fatalf("expected 2 progress messages, got %d: %#v", len(cli.messages), cli.messages)
+	}
+	if cli.messages[0] != "refreshed 1/2 resources" {
+		t.Fatalf("unexpected first message: %q", cli.messages[0])
+	}
+	if cli.messages[1] != "refreshed 2/2 resources" {
+		t.Fatalf("unexpected second message: %q", cli.messages[1])
+	}
+}
+
+func TestCountStateResources(t *testing.T) {
+	state := &terraform.State{
+		Modules: []*terraform.ModuleState{
+			{
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.a": {},
+					"aws_instance.b": {},
+				},
+			},
+			{
+				Resources: map[string]*terraform.ResourceState{
+					"aws_instance.c": {},
+				},
+			},
+		},
+	}
+
+	if got, want := countStateResources(state), 3; got != want {
+		t.Fatalf("countStateResources() = %d, want %d", got, want)
+	}
+
+	if got, want := countStateResources(nil), 0; got != want {
+		t.Fatalf("countStateResources(nil) = %d, want %d", got, want)
+	}
+}